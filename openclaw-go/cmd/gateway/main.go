@@ -5,7 +5,6 @@ package main
 
 import (
 	"context"
-	"log"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,38 +13,50 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/openclaw/openclaw-go/internal/gateway"
-	"go.uber.org/zap"
+	"github.com/openclaw/openclaw-go/internal/logging"
+	"go.uber.org/zap/zapcore"
 )
 
 func main() {
-	// Initialize logger
-	logger, err := zap.NewProduction()
-	if err != nil {
-		log.Fatalf("Failed to initialize logger: %v", err)
+	// Initialize the logging registry. Every subsystem gets its own
+	// named child logger from here; OPENCLAW_LOG_LEVEL sets the
+	// default verbosity for names that haven't been tuned via
+	// PUT /api/admin/log-level.
+	defaultLevel := zapcore.InfoLevel
+	if v := os.Getenv("OPENCLAW_LOG_LEVEL"); v != "" {
+		if lvl, err := logging.ParseLevel(v); err == nil {
+			defaultLevel = lvl
+		}
 	}
-	defer logger.Sync()
+	logs := logging.NewRegistry(defaultLevel)
+	logger := logs.Named("gateway")
 
 	// Load configuration
 	cfg := gateway.LoadConfig()
-	
+
 	// Set Gin mode
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	// Create gateway server
-	server := gateway.NewServer(cfg, logger)
+	server, err := gateway.NewServer(cfg, logs)
+	if err != nil {
+		logger.Error("Failed to create gateway server", "error", err)
+		os.Exit(1)
+	}
 
 	// Start server in goroutine
 	go func() {
 		if err := server.Start(); err != nil && err != http.ErrServerClosed {
-			logger.Fatal("Failed to start server", zap.Error(err))
+			logger.Error("Failed to start server", "error", err)
+			os.Exit(1)
 		}
 	}()
 
 	logger.Info("OpenClaw Gateway started",
-		zap.String("address", cfg.Address),
-		zap.String("environment", cfg.Environment),
+		"address", cfg.Address,
+		"environment", cfg.Environment,
 	)
 
 	// Wait for interrupt signal
@@ -60,7 +71,8 @@ func main() {
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
-		logger.Fatal("Server forced to shutdown", zap.Error(err))
+		logger.Error("Server forced to shutdown", "error", err)
+		os.Exit(1)
 	}
 
 	logger.Info("Server exited")
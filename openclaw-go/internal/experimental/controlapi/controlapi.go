@@ -0,0 +1,77 @@
+// Package controlapi implements a Clash-style observability API for
+// the gateway's MCP traffic, modelled on sing-box's TrafficController
+// and Clash API: it exposes live byte counters, a rolling log of
+// recent tool calls, a WebSocket log tap, and hot-reloadable routing
+// rules under /api/control.
+package controlapi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/openclaw/openclaw-go/internal/logging"
+	"github.com/openclaw/openclaw-go/internal/mcp"
+	mcprouter "github.com/openclaw/openclaw-go/internal/mcp/router"
+	"go.uber.org/zap/zapcore"
+)
+
+// Controller is the Clash-style control API surface. It implements
+// mcp.TrafficRecorder so it can be installed directly on an
+// mcp.Manager, and it owns the log tap wired into the gateway logger.
+type Controller struct {
+	logger logging.Logger
+	mcp    *mcp.Manager
+
+	traffic *trafficState
+	logTap  *logTap
+	rules   *ruleStore
+	router  *mcprouter.Router
+}
+
+// NewController creates a Controller bound to the given MCP manager.
+// logger is typically the gateway's root logger; NewController names
+// its child "controlapi". Call RegisterRoutes to mount its
+// HTTP/WebSocket endpoints and install SetTrafficRecorder(controller)
+// on the manager so calls flow through it.
+func NewController(m *mcp.Manager, logger logging.Logger) *Controller {
+	return &Controller{
+		logger:  logger.Named("controlapi"),
+		mcp:     m,
+		traffic: newTrafficState(),
+		logTap:  newLogTap(),
+		rules:   newRuleStore(),
+	}
+}
+
+// SetRouter binds the mcp/router.Router that PUT /api/control/rules
+// should push reloads to. Until this is called, PUT only updates the
+// control API's own view of the rules and Loader's output won't take
+// effect until the router's next SIGHUP-triggered reload.
+func (c *Controller) SetRouter(r *mcprouter.Router) {
+	c.router = r
+}
+
+// LogTap returns a zapcore.Core that can be teed into the gateway's
+// logger so every log record is also replayed to /api/control/logs
+// subscribers.
+func (c *Controller) LogTap() zapcore.Core {
+	return c.logTap
+}
+
+// RegisterRoutes mounts the control API under the given router group,
+// typically `router.Group("/api/control")`.
+func (c *Controller) RegisterRoutes(group *gin.RouterGroup) {
+	group.GET("/traffic", c.handleTraffic)
+	group.GET("/connections", c.handleListConnections)
+	group.DELETE("/connections/:id", c.handleDisconnect)
+	group.GET("/logs", c.handleLogs)
+	group.GET("/rules", c.handleGetRules)
+	group.PUT("/rules", c.handlePutRules)
+}
+
+func (c *Controller) handleDisconnect(ctx *gin.Context) {
+	id := ctx.Param("id")
+	c.mcp.Disconnect(id)
+	c.traffic.reset(id)
+	ctx.JSON(http.StatusOK, gin.H{"status": "disconnected", "server_id": id})
+}
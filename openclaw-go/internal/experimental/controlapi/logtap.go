@@ -0,0 +1,116 @@
+package controlapi
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap/zapcore"
+)
+
+// logTap is a zapcore.Core that fans every log entry out to whatever
+// WebSocket clients are currently subscribed via
+// GET /api/control/logs. It never fails a log write on its own -
+// slow or disconnected subscribers just drop frames.
+type logTap struct {
+	mu          sync.RWMutex
+	subscribers map[chan []byte]struct{}
+	enc         zapcore.Encoder
+}
+
+func newLogTap() *logTap {
+	encCfg := zapcore.EncoderConfig{
+		TimeKey:        "ts",
+		LevelKey:       "level",
+		MessageKey:     "msg",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+	return &logTap{
+		subscribers: make(map[chan []byte]struct{}),
+		enc:         zapcore.NewJSONEncoder(encCfg),
+	}
+}
+
+func (t *logTap) Enabled(zapcore.Level) bool { return true }
+
+func (t *logTap) With(fields []zapcore.Field) zapcore.Core {
+	return t
+}
+
+func (t *logTap) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	t.mu.RLock()
+	n := len(t.subscribers)
+	t.mu.RUnlock()
+	if n == 0 {
+		return ce
+	}
+	return ce.AddCore(entry, t)
+}
+
+func (t *logTap) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := t.enc.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+	line := append([]byte(nil), buf.Bytes()...)
+	buf.Free()
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for ch := range t.subscribers {
+		select {
+		case ch <- line:
+		default:
+			// slow subscriber: drop this line rather than block logging
+		}
+	}
+	return nil
+}
+
+func (t *logTap) Sync() error { return nil }
+
+func (t *logTap) subscribe() chan []byte {
+	ch := make(chan []byte, 256)
+	t.mu.Lock()
+	t.subscribers[ch] = struct{}{}
+	t.mu.Unlock()
+	return ch
+}
+
+func (t *logTap) unsubscribe(ch chan []byte) {
+	t.mu.Lock()
+	delete(t.subscribers, ch)
+	t.mu.Unlock()
+	close(ch)
+}
+
+// handleLogs streams live log entries as newline-delimited JSON
+// frames over a WebSocket, mirroring Clash's /logs tap.
+func (c *Controller) handleLogs(ctx *gin.Context) {
+	conn, err := trafficUpgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ch := c.logTap.subscribe()
+	defer c.logTap.unsubscribe(ch)
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case line, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, line); err != nil {
+				return
+			}
+		}
+	}
+}
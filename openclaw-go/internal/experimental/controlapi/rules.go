@@ -0,0 +1,96 @@
+package controlapi
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	mcprouter "github.com/openclaw/openclaw-go/internal/mcp/router"
+)
+
+// routingRule maps a tool-name namespace (a glob prefix, e.g.
+// "github.*") to the MCP server ID that should handle it. This is a
+// thin, hot-reloadable view over the same routing decisions the
+// mcp/router package makes; control-api exists to inspect and tweak
+// them, not to execute them.
+type routingRule struct {
+	Namespace string `json:"namespace"`
+	ServerID  string `json:"server_id"`
+}
+
+// ruleStore holds the current routing table in memory, swappable as
+// a whole via PUT /api/control/rules.
+type ruleStore struct {
+	mu    sync.RWMutex
+	rules []routingRule
+}
+
+func newRuleStore() *ruleStore {
+	return &ruleStore{}
+}
+
+func (r *ruleStore) get() []routingRule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]routingRule, len(r.rules))
+	copy(out, r.rules)
+	return out
+}
+
+func (r *ruleStore) set(rules []routingRule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = rules
+}
+
+// Loader returns the mcprouter.Loader backed by this controller's rule
+// store: both PUT /api/control/rules and the router's own
+// SIGHUP-triggered Reload end up reading the exact same state, so the
+// two "hot-reload" surfaces can never drift apart.
+func (c *Controller) Loader() mcprouter.Loader {
+	return func() ([]mcprouter.Rule, string, error) {
+		return toRouterRules(c.rules.get()), "", nil
+	}
+}
+
+// toRouterRules converts the control API's simplified namespace ->
+// server-ID mapping into mcprouter.Rule values, matching each
+// namespace as a tool-name glob.
+func toRouterRules(rules []routingRule) []mcprouter.Rule {
+	out := make([]mcprouter.Rule, len(rules))
+	for i, r := range rules {
+		out[i] = mcprouter.Rule{
+			Name:     r.Namespace,
+			Matchers: []mcprouter.Matcher{mcprouter.ToolGlob{Pattern: r.Namespace}},
+			Outbound: r.ServerID,
+		}
+	}
+	return out
+}
+
+func (c *Controller) handleGetRules(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"rules": c.rules.get()})
+}
+
+func (c *Controller) handlePutRules(ctx *gin.Context) {
+	var body struct {
+		Rules []routingRule `json:"rules"`
+	}
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.rules.set(body.Rules)
+
+	if c.router != nil {
+		if err := c.router.Reload(); err != nil {
+			c.logger.Error("control API: router reload after rule update failed", "error", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.logger.Info("control API: routing rules hot-reloaded", "count", len(body.Rules))
+	ctx.JSON(http.StatusOK, gin.H{"rules": c.rules.get()})
+}
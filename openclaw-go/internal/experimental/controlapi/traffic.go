@@ -0,0 +1,207 @@
+package controlapi
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// callRecord describes a single observed tool invocation, as stored
+// in the ring buffer of recent calls shared by all servers.
+type callRecord struct {
+	ServerID  string    `json:"server_id"`
+	Tool      string    `json:"tool"`
+	ArgsHash  string    `json:"args_hash"`
+	LatencyMs int64     `json:"latency_ms"`
+	Status    string    `json:"status"`
+	At        time.Time `json:"at"`
+}
+
+// serverStats is the per-server traffic counters exposed over
+// /api/control/traffic and /api/control/connections.
+type serverStats struct {
+	UploadBytes    int64
+	DownloadBytes  int64
+	ActiveRequests int64
+	TotalCalls     int64
+	LastActivity   time.Time
+}
+
+const ringSize = 256
+
+type trafficState struct {
+	mu    sync.RWMutex
+	stats map[string]*serverStats
+	ring  []callRecord // fixed-size ring buffer, oldest overwritten first
+	next  int
+	count int
+}
+
+func newTrafficState() *trafficState {
+	return &trafficState{
+		stats: make(map[string]*serverStats),
+		ring:  make([]callRecord, ringSize),
+	}
+}
+
+// BeginCall implements mcp.TrafficRecorder.
+func (c *Controller) BeginCall(serverID string) {
+	c.traffic.beginRequest(serverID)
+}
+
+// EndCall implements mcp.TrafficRecorder.
+func (c *Controller) EndCall(serverID string) {
+	c.traffic.endRequest(serverID)
+}
+
+// RecordCall implements mcp.TrafficRecorder.
+func (c *Controller) RecordCall(serverID, tool, argsHash string, latency time.Duration, status string, requestBytes, responseBytes int64) {
+	c.traffic.record(serverID, tool, argsHash, latency, status, requestBytes, responseBytes)
+}
+
+func (t *trafficState) record(serverID, tool, argsHash string, latency time.Duration, status string, requestBytes, responseBytes int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.stats[serverID]
+	if !ok {
+		s = &serverStats{}
+		t.stats[serverID] = s
+	}
+	s.UploadBytes += requestBytes
+	s.DownloadBytes += responseBytes
+	s.TotalCalls++
+	s.LastActivity = time.Now()
+
+	t.ring[t.next] = callRecord{
+		ServerID:  serverID,
+		Tool:      tool,
+		ArgsHash:  argsHash,
+		LatencyMs: latency.Milliseconds(),
+		Status:    status,
+		At:        s.LastActivity,
+	}
+	t.next = (t.next + 1) % len(t.ring)
+	if t.count < len(t.ring) {
+		t.count++
+	}
+}
+
+// beginRequest/endRequest track the in-flight request gauge separate
+// from RecordCall, which only fires once the call has completed.
+func (t *trafficState) beginRequest(serverID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s, ok := t.stats[serverID]
+	if !ok {
+		s = &serverStats{}
+		t.stats[serverID] = s
+	}
+	s.ActiveRequests++
+}
+
+func (t *trafficState) endRequest(serverID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s, ok := t.stats[serverID]; ok && s.ActiveRequests > 0 {
+		s.ActiveRequests--
+	}
+}
+
+func (t *trafficState) reset(serverID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.stats, serverID)
+}
+
+func (t *trafficState) snapshot() map[string]serverStats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	out := make(map[string]serverStats, len(t.stats))
+	for id, s := range t.stats {
+		out[id] = *s
+	}
+	return out
+}
+
+func (t *trafficState) recentCalls() []callRecord {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	out := make([]callRecord, 0, t.count)
+	for i := 0; i < t.count; i++ {
+		idx := (t.next - 1 - i + len(t.ring)) % len(t.ring)
+		out = append(out, t.ring[idx])
+	}
+	return out
+}
+
+var trafficUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// handleTraffic streams a JSON snapshot of per-server traffic stats
+// once per second over a WebSocket, mirroring Clash's /traffic feed.
+func (c *Controller) handleTraffic(ctx *gin.Context) {
+	conn, err := trafficUpgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		c.logger.Warn("traffic ws upgrade failed", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-ticker.C:
+			snapshot := c.traffic.snapshot()
+			if err := conn.WriteJSON(gin.H{"servers": snapshot, "recent_calls": c.traffic.recentCalls()}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// handleListConnections reports one "connection" per configured MCP
+// server, combining its live state with accumulated traffic counters.
+func (c *Controller) handleListConnections(ctx *gin.Context) {
+	snapshot := c.traffic.snapshot()
+
+	type connection struct {
+		ID             string    `json:"id"`
+		Name           string    `json:"name"`
+		Transport      string    `json:"transport"`
+		Connected      bool      `json:"connected"`
+		UploadBytes    int64     `json:"upload_bytes"`
+		DownloadBytes  int64     `json:"download_bytes"`
+		ActiveRequests int64     `json:"active_requests"`
+		TotalCalls     int64     `json:"total_calls"`
+		LastActivity   time.Time `json:"last_activity,omitempty"`
+	}
+
+	conns := make([]connection, 0, len(c.mcp.ListServers()))
+	for _, srv := range c.mcp.ListServers() {
+		stats := snapshot[srv.ID]
+		conns = append(conns, connection{
+			ID:             srv.ID,
+			Name:           srv.Name,
+			Transport:      srv.Transport,
+			Connected:      c.mcp.IsConnected(srv.ID),
+			UploadBytes:    stats.UploadBytes,
+			DownloadBytes:  stats.DownloadBytes,
+			ActiveRequests: stats.ActiveRequests,
+			TotalCalls:     stats.TotalCalls,
+			LastActivity:   stats.LastActivity,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"connections": conns})
+}
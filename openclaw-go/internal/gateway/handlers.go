@@ -6,39 +6,31 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"github.com/openclaw/openclaw-go/internal/logging"
+	mcprouter "github.com/openclaw/openclaw-go/internal/mcp/router"
+	"github.com/openclaw/openclaw-go/internal/session"
 )
 
 var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
 		return true // Allow all origins in development
 	},
+	Subprotocols: []string{session.ProtocolVersion},
 }
 
-// WebSocket handler
+// handleWebSocket upgrades to the session-oriented JSON-RPC protocol
+// (see internal/session) and blocks for the lifetime of the
+// connection. Pass ?resume=<token> to reattach to a session from a
+// previous connection instead of starting a fresh one.
 func (s *Server) handleWebSocket(c *gin.Context) {
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		s.logger.Error("WebSocket upgrade failed", err)
+		s.logger.Error("WebSocket upgrade failed", "error", err)
 		return
 	}
 	defer conn.Close()
 
-	s.logger.Info("WebSocket connection established")
-
-	// Handle WebSocket connection
-	for {
-		messageType, message, err := conn.ReadMessage()
-		if err != nil {
-			s.logger.Error("WebSocket read error", err)
-			return
-		}
-
-		// Echo message back for now
-		if err := conn.WriteMessage(messageType, message); err != nil {
-			s.logger.Error("WebSocket write error", err)
-			return
-		}
-	}
+	s.sessions.Accept(conn, c.Query("resume"), c.GetString("caller_identity"))
 }
 
 // MCP Handlers
@@ -49,14 +41,14 @@ func (s *Server) handleMCPListServers(c *gin.Context) {
 
 func (s *Server) handleMCPConnect(c *gin.Context) {
 	serverID := c.Param("id")
-	
+
 	if err := s.mcp.Connect(serverID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"status": "connected",
+		"status":    "connected",
 		"server_id": serverID,
 		"timestamp": time.Now().Unix(),
 	})
@@ -65,16 +57,16 @@ func (s *Server) handleMCPConnect(c *gin.Context) {
 func (s *Server) handleMCPDisconnect(c *gin.Context) {
 	serverID := c.Param("id")
 	s.mcp.Disconnect(serverID)
-	
+
 	c.JSON(http.StatusOK, gin.H{
-		"status": "disconnected",
+		"status":    "disconnected",
 		"server_id": serverID,
 	})
 }
 
 func (s *Server) handleMCPCallTool(c *gin.Context) {
 	serverID := c.Param("id")
-	
+
 	var req struct {
 		Tool string                 `json:"tool"`
 		Args map[string]interface{} `json:"args"`
@@ -94,6 +86,56 @@ func (s *Server) handleMCPCallTool(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// handleMCPRoutedCall dispatches a tool call through the mcp/router
+// rule set instead of requiring the caller to name a serverID. Pass
+// ?dry-run=1 to see which rule (and server) would handle the call
+// without actually invoking the tool.
+func (s *Server) handleMCPRoutedCall(c *gin.Context) {
+	var req struct {
+		Tool string                 `json:"tool"`
+		Args map[string]interface{} `json:"args"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	toolReq := mcprouter.ToolRequest{
+		Tool:     req.Tool,
+		Args:     req.Args,
+		CallerID: c.GetString("caller_identity"),
+		Time:     time.Now(),
+	}
+
+	outbound, rule, err := s.mcpRouter.Match(c.Request.Context(), toolReq)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	matchedRule := ""
+	if rule != nil {
+		matchedRule = rule.Name
+	}
+
+	if c.Query("dry-run") != "" {
+		c.JSON(http.StatusOK, gin.H{
+			"matched_rule": matchedRule,
+			"server_id":    outbound,
+		})
+		return
+	}
+
+	result, err := s.mcp.CallTool(outbound, req.Tool, req.Args)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 func (s *Server) handleMCPListTools(c *gin.Context) {
 	serverID := c.Param("id")
 	tools, err := s.mcp.ListTools(serverID)
@@ -120,14 +162,14 @@ func (s *Server) handleMCPListResources(c *gin.Context) {
 func (s *Server) handleListContainers(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"containers": []interface{}{},
-		"count": 0,
+		"count":      0,
 	})
 }
 
 func (s *Server) handleGetRuntimeInfo(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
-		"runtime": "docker",
-		"version": "24.0.0",
+		"runtime":   "docker",
+		"version":   "24.0.0",
 		"available": true,
 	})
 }
@@ -136,25 +178,25 @@ func (s *Server) handleGetContainerLogs(c *gin.Context) {
 	containerID := c.Param("id")
 	c.JSON(http.StatusOK, gin.H{
 		"container_id": containerID,
-		"logs": "Container logs would appear here...",
+		"logs":         "Container logs would appear here...",
 	})
 }
 
 func (s *Server) handleStopContainer(c *gin.Context) {
 	containerID := c.Param("id")
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
+		"success":      true,
 		"container_id": containerID,
-		"message": "Container stopped",
+		"message":      "Container stopped",
 	})
 }
 
 func (s *Server) handleRemoveContainer(c *gin.Context) {
 	containerID := c.Param("id")
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
+		"success":      true,
 		"container_id": containerID,
-		"message": "Container removed",
+		"message":      "Container removed",
 	})
 }
 
@@ -162,15 +204,15 @@ func (s *Server) handleRemoveContainer(c *gin.Context) {
 func (s *Server) handleListArticles(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"articles": []interface{}{},
-		"count": 0,
+		"count":    0,
 	})
 }
 
 func (s *Server) handleGetArticle(c *gin.Context) {
 	articleID := c.Param("id")
 	c.JSON(http.StatusOK, gin.H{
-		"id": articleID,
-		"title": "Article Title",
+		"id":      articleID,
+		"title":   "Article Title",
 		"content": "Article content...",
 	})
 }
@@ -178,30 +220,66 @@ func (s *Server) handleGetArticle(c *gin.Context) {
 func (s *Server) handleSaveArticle(c *gin.Context) {
 	articleID := c.Param("id")
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
+		"success":    true,
 		"article_id": articleID,
-		"saved": true,
+		"saved":      true,
 	})
 }
 
 func (s *Server) handleListDigests(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"digests": []interface{}{},
-		"count": 0,
+		"count":   0,
 	})
 }
 
 func (s *Server) handleGenerateDigest(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
+		"success":   true,
 		"digest_id": "digest-123",
-		"status": "generating",
+		"status":    "generating",
 	})
 }
 
 func (s *Server) handleGetTrends(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"trends": []interface{}{},
-		"count": 0,
+		"count":  0,
 	})
 }
+
+// handleGetLogLevels reports the current verbosity of every named
+// logger that has been configured or queried so far.
+func (s *Server) handleGetLogLevels(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"levels": s.logs.Levels()})
+}
+
+// handleSetLogLevel changes a single named logger's verbosity at
+// runtime, e.g. {"logger":"mcp.client.foo","level":"debug"}.
+func (s *Server) handleSetLogLevel(c *gin.Context) {
+	var req struct {
+		Logger string `json:"logger"`
+		Level  string `json:"level"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Logger == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "logger name is required"})
+		return
+	}
+
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.logs.SetLevel(req.Logger, level)
+	s.logger.Info("admin: log level changed", "logger", req.Logger, "level", req.Level)
+
+	c.JSON(http.StatusOK, gin.H{"logger": req.Logger, "level": req.Level})
+}
@@ -3,28 +3,60 @@ package gateway
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/openclaw/openclaw-go/internal/experimental/controlapi"
+	"github.com/openclaw/openclaw-go/internal/logging"
 	"github.com/openclaw/openclaw-go/internal/mcp"
-	"go.uber.org/zap"
+	mcprouter "github.com/openclaw/openclaw-go/internal/mcp/router"
+	"github.com/openclaw/openclaw-go/internal/session"
 )
 
 // Config holds gateway configuration
 type Config struct {
-	Address     string
-	Environment string
-	MCPConfig   mcp.Config
+	Address       string
+	Environment   string
+	MCPConfig     mcp.Config
+	TLS           TLSConfig
+	SessionSecret string // signs WebSocket session resumption tokens
+}
+
+// TLSConfig controls how the gateway terminates HTTPS. Mode selects
+// between "disabled" (plain HTTP), "file" (a static cert/key pair) and
+// "acme" (golang.org/x/crypto/acme/autocert), mirroring the way Wings
+// picks a certificate source in its root command.
+type TLSConfig struct {
+	Mode string
+
+	CertFile string // file mode
+	KeyFile  string // file mode
+
+	ACMECacheDir string   // acme mode
+	ACMEHosts    []string // acme mode: autocert.HostWhitelist allow-list
+
+	MTLSEnabled           bool
+	MTLSClientCAFile      string
+	MTLSAllowedIdentities []string // CN/SAN allow-list for /api/mcp/** and /ws
 }
 
 // Server represents the gateway server
 type Server struct {
-	router *gin.Engine
-	server *http.Server
-	config *Config
-	logger *zap.Logger
-	mcp    *mcp.Manager
+	router         *gin.Engine
+	server         *http.Server
+	redirectServer *http.Server
+	config         *Config
+	logger         logging.Logger
+	logs           *logging.Registry
+	mcp            *mcp.Manager
+	control        *controlapi.Controller
+	mcpRouter      *mcprouter.Router
+	sessions       *session.Manager
 }
 
 // LoadConfig loads configuration from environment and files
@@ -33,43 +65,115 @@ func LoadConfig() *Config {
 		Address:     getEnv("OPENCLAW_GATEWAY_ADDR", ":8080"),
 		Environment: getEnv("OPENCLAW_ENV", "development"),
 		MCPConfig:   mcp.LoadConfig(),
+		TLS: TLSConfig{
+			Mode:                  getEnv("OPENCLAW_TLS_MODE", "disabled"),
+			CertFile:              getEnv("OPENCLAW_TLS_CERT", ""),
+			KeyFile:               getEnv("OPENCLAW_TLS_KEY", ""),
+			ACMECacheDir:          getEnv("OPENCLAW_ACME_CACHE_DIR", "./acme-cache"),
+			ACMEHosts:             getEnvList("OPENCLAW_ACME_HOSTS"),
+			MTLSEnabled:           getEnvBool("OPENCLAW_MTLS_ENABLED", false),
+			MTLSClientCAFile:      getEnv("OPENCLAW_MTLS_CLIENT_CA", ""),
+			MTLSAllowedIdentities: getEnvList("OPENCLAW_MTLS_ALLOWED_IDENTITIES"),
+		},
+		SessionSecret: getEnv("OPENCLAW_SESSION_SECRET", ""),
 	}
 }
 
-// NewServer creates a new gateway server
-func NewServer(config *Config, logger *zap.Logger) *Server {
+// NewServer creates a new gateway server. logs is the registry
+// backing every named subsystem logger (gateway, mcp, mcp.router,
+// gateway.controlapi, ...); it also backs the /api/admin/log-level endpoints.
+// It returns an error if the mcp router's initial rule load fails.
+func NewServer(config *Config, logs *logging.Registry) (*Server, error) {
+	logger := logs.Named("gateway")
+
+	mcpManager := mcp.NewManager(config.MCPConfig, logs.Named("mcp"))
+	control := controlapi.NewController(mcpManager, logger)
+	mcpManager.SetTrafficRecorder(control)
+	// Tee every logger created from here on into the control API's
+	// WebSocket log feed. Loggers already handed out above (gateway,
+	// mcp) predate the tap and won't be mirrored; that's an accepted
+	// bootstrap gap, not a bug.
+	logs.AddTap(control.LogTap())
+
 	router := gin.New()
 	router.Use(gin.Recovery())
-	router.Use(loggingMiddleware(logger))
+	router.Use(loggingMiddleware(logs.Named("gateway.http")))
+
+	// control's rule store backs the router's Loader, so PUT
+	// /api/control/rules and the router's own SIGHUP reload both read
+	// from the same state instead of drifting apart.
+	mcpRouter, err := mcprouter.New(logs.Named("mcp"), control.Loader())
+	if err != nil {
+		return nil, fmt.Errorf("gateway: failed to initialize mcp router: %w", err)
+	}
+	control.SetRouter(mcpRouter)
+	mcpRouter.WatchReloadSignals(context.Background())
+
+	sessions := session.NewManager(mcpManager, logs.Named("gateway.ws"), session.Config{
+		Secret: []byte(config.SessionSecret),
+	})
 
 	server := &Server{
-		router: router,
-		config: config,
-		logger: logger,
-		mcp:    mcp.NewManager(config.MCPConfig, logger),
+		router:    router,
+		config:    config,
+		logger:    logger,
+		logs:      logs,
+		mcp:       mcpManager,
+		control:   control,
+		mcpRouter: mcpRouter,
+		sessions:  sessions,
 	}
 
 	server.setupRoutes()
 
-	return server
+	return server, nil
 }
 
-// Start starts the server
+// Start starts the server. When s.config.TLS.Mode is "file" or
+// "acme", it also starts an HTTP redirector on :80 (which doubles as
+// the ACME HTTP-01 challenge responder in "acme" mode).
 func (s *Server) Start() error {
+	tlsConfig, acmeManager, err := buildTLSConfig(s.config.TLS)
+	if err != nil {
+		return err
+	}
+
 	s.server = &http.Server{
-		Addr:    s.config.Address,
-		Handler: s.router,
+		Addr:      s.config.Address,
+		Handler:   s.router,
+		TLSConfig: tlsConfig,
+	}
+
+	if tlsConfig == nil {
+		s.logger.Info("Starting gateway server", "address", s.config.Address, "tls", false)
+		return s.server.ListenAndServe()
 	}
 
-	s.logger.Info("Starting gateway server",
-		zap.String("address", s.config.Address),
-	)
+	s.redirectServer = &http.Server{
+		Addr:    ":80",
+		Handler: redirectToHTTPSHandler(acmeManager),
+	}
+	go func() {
+		if err := s.redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("HTTP redirector failed", "error", err)
+		}
+	}()
 
-	return s.server.ListenAndServe()
+	s.logger.Info("Starting gateway server", "address", s.config.Address, "tls", true, "tls_mode", s.config.TLS.Mode)
+	return s.server.ListenAndServeTLS("", "")
 }
 
-// Shutdown gracefully shuts down the server
+// Shutdown gracefully shuts down the server, draining the HTTP
+// redirector too if TLS is enabled.
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.mcpRouter.StopWatchingReloadSignals()
+
+	if s.redirectServer != nil {
+		if err := s.redirectServer.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+
 	return s.server.Shutdown(ctx)
 }
 
@@ -79,19 +183,32 @@ func (s *Server) setupRoutes() {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
-	// API routes
-	api := s.router.Group("/api")
+	// MCP routes require a verified mTLS client certificate when
+	// s.config.TLS.MTLSEnabled is set; requireClientIdentity is a
+	// no-op otherwise.
+	mcpAPI := s.router.Group("/api/mcp", s.requireClientIdentity())
+	{
+		mcpAPI.GET("/servers", s.handleMCPListServers)
+		mcpAPI.POST("/servers/:id/connect", s.handleMCPConnect)
+		mcpAPI.POST("/servers/:id/call", s.handleMCPCallTool)
+		mcpAPI.POST("/call", s.handleMCPRoutedCall)
+	}
+
+	// Clash-style traffic/rules control API
+	s.control.RegisterRoutes(s.router.Group("/api/control"))
+
+	// Admin endpoints for dynamic log-level control
+	admin := s.router.Group("/api/admin")
 	{
-		api.GET("/mcp/servers", s.handleMCPListServers)
-		api.POST("/mcp/servers/:id/connect", s.handleMCPConnect)
-		api.POST("/mcp/servers/:id/call", s.handleMCPCallTool)
+		admin.GET("/log-level", s.handleGetLogLevels)
+		admin.PUT("/log-level", s.handleSetLogLevel)
 	}
 
 	// WebSocket endpoint
-	s.router.GET("/ws", s.handleWebSocket)
+	s.router.GET("/ws", s.requireClientIdentity(), s.handleWebSocket)
 }
 
-func loggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
+func loggingMiddleware(logger logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -99,17 +216,46 @@ func loggingMiddleware(logger *zap.Logger) gin.HandlerFunc {
 		c.Next()
 
 		logger.Info("HTTP request",
-			zap.String("method", c.Request.Method),
-			zap.String("path", path),
-			zap.Int("status", c.Writer.Status()),
-			zap.Duration("duration", time.Since(start)),
+			"method", c.Request.Method,
+			"path", path,
+			"status", c.Writer.Status(),
+			"duration", time.Since(start),
 		)
 	}
 }
 
 func getEnv(key, defaultValue string) string {
-	if value := "os".Getenv(key); value != "" {
+	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvList splits a comma-separated env var into a trimmed,
+// non-empty string slice, or nil if unset.
+func getEnvList(key string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,138 @@
+package gateway
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// buildTLSConfig turns a TLSConfig into a *tls.Config ready for
+// http.Server.TLSConfig. It returns (nil, nil, nil) when TLS is
+// disabled. In "acme" mode it also returns the autocert.Manager, so
+// Start can route the :80 redirector's HTTP-01 challenges through it.
+func buildTLSConfig(cfg TLSConfig) (*tls.Config, *autocert.Manager, error) {
+	switch cfg.Mode {
+	case "", "disabled":
+		return nil, nil, nil
+
+	case "file":
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("gateway: load TLS cert/key: %w", err)
+		}
+		tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+		if err := applyMTLS(tlsConfig, cfg); err != nil {
+			return nil, nil, err
+		}
+		return tlsConfig, nil, nil
+
+	case "acme":
+		if len(cfg.ACMEHosts) == 0 {
+			return nil, nil, fmt.Errorf("gateway: acme TLS mode requires at least one host in OPENCLAW_ACME_HOSTS")
+		}
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			Cache:      autocert.DirCache(cfg.ACMECacheDir),
+			HostPolicy: autocert.HostWhitelist(cfg.ACMEHosts...),
+		}
+		tlsConfig := manager.TLSConfig()
+		if err := applyMTLS(tlsConfig, cfg); err != nil {
+			return nil, nil, err
+		}
+		return tlsConfig, manager, nil
+
+	default:
+		return nil, nil, fmt.Errorf("gateway: unknown TLS mode %q", cfg.Mode)
+	}
+}
+
+// applyMTLS layers client-certificate authentication onto tlsConfig
+// when cfg.MTLSEnabled. This only proves the client's certificate
+// chains to a trusted CA; matching the certificate's identity against
+// cfg.MTLSAllowedIdentities happens in requireClientIdentity.
+func applyMTLS(tlsConfig *tls.Config, cfg TLSConfig) error {
+	if !cfg.MTLSEnabled {
+		return nil
+	}
+
+	pem, err := os.ReadFile(cfg.MTLSClientCAFile)
+	if err != nil {
+		return fmt.Errorf("gateway: read mTLS client CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("gateway: no certificates found in %s", cfg.MTLSClientCAFile)
+	}
+
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return nil
+}
+
+// redirectToHTTPSHandler serves a plain 301 redirect to the HTTPS
+// version of every request. In acme mode, manager is non-nil and gets
+// first refusal so Let's Encrypt's HTTP-01 challenge requests are
+// answered instead of redirected.
+func redirectToHTTPSHandler(manager *autocert.Manager) http.Handler {
+	redirect := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target := "https://" + r.Host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+	})
+	if manager != nil {
+		return manager.HTTPHandler(redirect)
+	}
+	return redirect
+}
+
+// requireClientIdentity is a no-op unless mTLS is enabled, in which
+// case it requires the request to carry a client certificate (already
+// chain-verified by the TLS handshake via tls.Config.ClientCAs) whose
+// CN or a SAN DNS name appears in MTLSAllowedIdentities, and exposes
+// that identity to handlers via c.Get("caller_identity").
+func (s *Server) requireClientIdentity() gin.HandlerFunc {
+	allowed := make(map[string]struct{}, len(s.config.TLS.MTLSAllowedIdentities))
+	for _, id := range s.config.TLS.MTLSAllowedIdentities {
+		allowed[id] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if !s.config.TLS.MTLSEnabled {
+			c.Next()
+			return
+		}
+
+		if c.Request.TLS == nil || len(c.Request.TLS.PeerCertificates) == 0 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "client certificate required"})
+			return
+		}
+
+		identity, ok := matchIdentity(c.Request.TLS.PeerCertificates[0], allowed)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "client certificate not authorized"})
+			return
+		}
+
+		c.Set("caller_identity", identity)
+		c.Next()
+	}
+}
+
+// matchIdentity reports whether cert's CN or any SAN DNS name is in
+// allowed, returning the identity string that matched.
+func matchIdentity(cert *x509.Certificate, allowed map[string]struct{}) (string, bool) {
+	if _, ok := allowed[cert.Subject.CommonName]; ok {
+		return cert.Subject.CommonName, true
+	}
+	for _, name := range cert.DNSNames {
+		if _, ok := allowed[name]; ok {
+			return name, true
+		}
+	}
+	return "", false
+}
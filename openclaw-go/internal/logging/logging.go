@@ -0,0 +1,199 @@
+// Package logging provides a small hclog-style leveled logging
+// interface over zap, following the structured-logging switch Nomad
+// made away from ad-hoc loggers: every subsystem gets its own named
+// child logger, and each name's verbosity can be changed at runtime
+// without touching the others.
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TraceLevel sits one notch below zapcore.DebugLevel so Trace can be
+// gated by the same zap.AtomicLevel used for Debug/Info/Warn/Error.
+const TraceLevel = zapcore.Level(-2)
+
+// Logger is a leveled logger whose fields are passed as alternating
+// key/value pairs, in the style of hclog and zap's SugaredLogger,
+// rather than strongly-typed zap.Field values.
+type Logger interface {
+	Trace(msg string, kv ...interface{})
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+
+	// With returns a logger that always includes the given key/value
+	// pairs.
+	With(kv ...interface{}) Logger
+	// Named returns a child logger whose name is this logger's name
+	// joined to sub with a dot, e.g. "mcp".Named("router") = "mcp.router".
+	// The child has its own independently adjustable level.
+	Named(sub string) Logger
+}
+
+// ParseLevel parses a level name ("trace", "debug", "info", "warn"/
+// "warning", "error") as accepted by the admin log-level API.
+func ParseLevel(s string) (zapcore.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace":
+		return TraceLevel, nil
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info":
+		return zapcore.InfoLevel, nil
+	case "warn", "warning":
+		return zapcore.WarnLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	default:
+		return 0, fmt.Errorf("logging: unknown level %q", s)
+	}
+}
+
+func levelString(l zapcore.Level) string {
+	if l == TraceLevel {
+		return "trace"
+	}
+	return l.String()
+}
+
+// Registry owns one independently-levelled zap core per named
+// logger. Each name's zap.AtomicLevel is created lazily and cached,
+// so that repeated calls to Named with the same name share one
+// zap.AtomicLevel - and therefore one on/off switch - even though
+// each call builds a new Logger.
+type Registry struct {
+	encoder      zapcore.Encoder
+	sink         zapcore.WriteSyncer
+	defaultLevel zapcore.Level
+
+	mu     sync.RWMutex
+	levels map[string]*zap.AtomicLevel
+	tap    zapcore.Core
+}
+
+// NewRegistry creates a Registry that writes JSON to stdout, with
+// defaultLevel used for any logger name that hasn't been configured
+// explicitly.
+func NewRegistry(defaultLevel zapcore.Level) *Registry {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	return &Registry{
+		encoder:      zapcore.NewJSONEncoder(cfg),
+		sink:         zapcore.AddSync(os.Stdout),
+		defaultLevel: defaultLevel,
+		levels:       make(map[string]*zap.AtomicLevel),
+	}
+}
+
+// Named returns the (possibly newly created) logger for name.
+func (r *Registry) Named(name string) Logger {
+	return &zapLogger{registry: r, name: name, logger: r.coreLoggerFor(name)}
+}
+
+func (r *Registry) coreLoggerFor(name string) *zap.Logger {
+	level := r.atomicLevel(name)
+	core := zapcore.NewCore(r.encoder, r.sink, level)
+
+	r.mu.RLock()
+	tap := r.tap
+	r.mu.RUnlock()
+	if tap != nil {
+		core = zapcore.NewTee(core, tap)
+	}
+
+	return zap.New(core, zap.AddCaller()).Named(name)
+}
+
+// AddTap tees every subsequently created named logger's output into
+// an additional zapcore.Core, e.g. the control API's WebSocket log
+// feed. Loggers already handed out via Named are unaffected.
+func (r *Registry) AddTap(core zapcore.Core) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tap == nil {
+		r.tap = core
+	} else {
+		r.tap = zapcore.NewTee(r.tap, core)
+	}
+}
+
+func (r *Registry) atomicLevel(name string) *zap.AtomicLevel {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if level, ok := r.levels[name]; ok {
+		return level
+	}
+	level := zap.NewAtomicLevelAt(r.defaultLevel)
+	r.levels[name] = &level
+	return &level
+}
+
+// SetLevel atomically swaps the verbosity of a named logger,
+// affecting every Logger previously handed out for that name. Unknown
+// names are created (pre-seeded) rather than rejected, since a
+// subsystem's logger (e.g. a not-yet-connected mcp.client.<id>) may
+// not exist yet.
+func (r *Registry) SetLevel(name string, level zapcore.Level) {
+	r.atomicLevel(name).SetLevel(level)
+}
+
+// Levels returns a snapshot of every named logger's current level,
+// for GET /api/admin/log-level.
+func (r *Registry) Levels() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make(map[string]string, len(r.levels))
+	for name, level := range r.levels {
+		out[name] = levelString(level.Level())
+	}
+	return out
+}
+
+type zapLogger struct {
+	registry *Registry
+	name     string
+	logger   *zap.Logger
+}
+
+func (l *zapLogger) Trace(msg string, kv ...interface{}) {
+	if ce := l.logger.Check(TraceLevel, msg); ce != nil {
+		ce.Write(sweeten(kv)...)
+	}
+}
+
+func (l *zapLogger) Debug(msg string, kv ...interface{}) { l.logger.Sugar().Debugw(msg, kv...) }
+func (l *zapLogger) Info(msg string, kv ...interface{})  { l.logger.Sugar().Infow(msg, kv...) }
+func (l *zapLogger) Warn(msg string, kv ...interface{})  { l.logger.Sugar().Warnw(msg, kv...) }
+func (l *zapLogger) Error(msg string, kv ...interface{}) { l.logger.Sugar().Errorw(msg, kv...) }
+
+func (l *zapLogger) With(kv ...interface{}) Logger {
+	return &zapLogger{registry: l.registry, name: l.name, logger: l.logger.Sugar().With(kv...).Desugar()}
+}
+
+func (l *zapLogger) Named(sub string) Logger {
+	full := sub
+	if l.name != "" {
+		full = l.name + "." + sub
+	}
+	return &zapLogger{registry: l.registry, name: full, logger: l.registry.coreLoggerFor(full)}
+}
+
+func sweeten(kv []interface{}) []zap.Field {
+	fields := make([]zap.Field, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, _ := kv[i].(string)
+		fields = append(fields, zap.Any(key, kv[i+1]))
+	}
+	return fields
+}
@@ -0,0 +1,325 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/openclaw/openclaw-go/internal/logging"
+)
+
+// defaultCallTimeout bounds how long a single request/response round
+// trip is allowed to take when the caller doesn't supply a context
+// deadline of their own.
+const defaultCallTimeout = 30 * time.Second
+
+// Client is a JSON-RPC 2.0 client speaking the Model Context
+// Protocol over one of the stdio, sse or streamable-http transports.
+// A single Client multiplexes any number of concurrent requests over
+// its transport using monotonically increasing request IDs.
+type Client struct {
+	config ServerConfig
+	logger logging.Logger
+
+	mu           sync.RWMutex
+	transport    transport
+	connected    bool
+	protocolVer  string
+	serverInfo   clientInfo
+	capabilities map[string]any
+
+	nextID  int64
+	pending sync.Map // int64 -> chan *response
+
+	notificationHandler func(method string, params json.RawMessage)
+}
+
+// OnNotification installs a handler invoked for every server-initiated
+// notification (progress, log, resource updates, ...). Manager uses it
+// to relay server push traffic to its own notification handler.
+func (c *Client) OnNotification(handler func(method string, params json.RawMessage)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notificationHandler = handler
+}
+
+func newClient(cfg ServerConfig, logger logging.Logger) *Client {
+	return &Client{
+		config: cfg,
+		logger: logger.Named("client." + cfg.ID),
+	}
+}
+
+// Connect dials the configured transport and performs the MCP
+// "initialize" handshake, caching the negotiated protocol version and
+// server capabilities.
+func (c *Client) Connect(ctx context.Context) error {
+	t, err := newTransport(c.config)
+	if err != nil {
+		return err
+	}
+
+	if err := t.Start(ctx); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.transport = t
+	c.mu.Unlock()
+
+	go c.readLoop(t)
+
+	initCtx, cancel := context.WithTimeout(ctx, defaultCallTimeout)
+	defer cancel()
+
+	var result initializeResult
+	if err := c.call(initCtx, "initialize", initializeParams{
+		ProtocolVersion: protocolVersion,
+		Capabilities:    map[string]any{},
+		ClientInfo:      clientInfo{Name: "openclaw-gateway", Version: "1.0.0"},
+	}, &result); err != nil {
+		t.Close()
+		return fmt.Errorf("mcp: initialize %q: %w", c.config.ID, err)
+	}
+
+	if err := c.notify(ctx, "notifications/initialized", nil); err != nil {
+		t.Close()
+		return fmt.Errorf("mcp: initialized notification %q: %w", c.config.ID, err)
+	}
+
+	c.mu.Lock()
+	c.connected = true
+	c.protocolVer = result.ProtocolVersion
+	c.serverInfo = result.ServerInfo
+	c.capabilities = result.Capabilities
+	c.mu.Unlock()
+
+	return nil
+}
+
+// Connected reports whether the initialize handshake has completed
+// and the transport is still believed to be live.
+func (c *Client) Connected() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.connected
+}
+
+// ConnectWithBackoff retries Connect with exponential backoff
+// (capped at 30s) until ctx is cancelled or the connection succeeds.
+func (c *Client) ConnectWithBackoff(ctx context.Context) error {
+	backoff := time.Second
+	const maxBackoff = 30 * time.Second
+
+	for {
+		err := c.Connect(ctx)
+		if err == nil {
+			return nil
+		}
+
+		c.logger.Warn("mcp: connect failed, retrying",
+			"server_id", c.config.ID,
+			"backoff", backoff,
+			"error", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// readLoop demultiplexes inbound transport messages: responses are
+// routed to the pending caller by ID, and notifications are dispatched
+// to the (optional) OnNotification handler.
+func (c *Client) readLoop(t transport) {
+	for raw := range t.Inbound() {
+		var env envelope
+		if err := json.Unmarshal(raw, &env); err != nil {
+			c.logger.Warn("mcp: malformed message", "server_id", c.config.ID, "error", err)
+			continue
+		}
+
+		if env.ID == nil {
+			var n notification
+			if err := json.Unmarshal(raw, &n); err == nil {
+				c.handleNotification(n)
+			}
+			continue
+		}
+
+		var resp response
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			continue
+		}
+
+		if ch, ok := c.pending.LoadAndDelete(resp.ID); ok {
+			ch.(chan *response) <- &resp
+		}
+	}
+
+	c.mu.Lock()
+	c.connected = false
+	c.mu.Unlock()
+}
+
+func (c *Client) handleNotification(n notification) {
+	c.mu.RLock()
+	handler := c.notificationHandler
+	c.mu.RUnlock()
+
+	if handler != nil {
+		handler(n.Method, n.Params)
+	}
+}
+
+// call issues a JSON-RPC request and unmarshals the result into out
+// (which may be nil to discard it).
+func (c *Client) call(ctx context.Context, method string, params, out interface{}) error {
+	c.mu.RLock()
+	t := c.transport
+	c.mu.RUnlock()
+	if t == nil {
+		return fmt.Errorf("mcp: client %q not connected", c.config.ID)
+	}
+
+	id := atomic.AddInt64(&c.nextID, 1)
+	ch := make(chan *response, 1)
+	c.pending.Store(id, ch)
+	defer c.pending.Delete(id)
+
+	reqID := id
+	payload, err := json.Marshal(request{
+		JSONRPC: "2.0",
+		ID:      &reqID,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := t.Send(ctx, payload); err != nil {
+		return err
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if out == nil || len(resp.Result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(resp.Result, out)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// notify sends a JSON-RPC notification (no response expected).
+func (c *Client) notify(ctx context.Context, method string, params interface{}) error {
+	c.mu.RLock()
+	t := c.transport
+	c.mu.RUnlock()
+	if t == nil {
+		return fmt.Errorf("mcp: client %q not connected", c.config.ID)
+	}
+
+	payload, err := json.Marshal(request{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+	return t.Send(ctx, payload)
+}
+
+// ListTools returns the tools advertised by the connected server.
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var result listToolsResult
+	if err := c.call(ctx, "tools/list", struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Tools, nil
+}
+
+// ListResources returns the resources advertised by the connected
+// server.
+func (c *Client) ListResources(ctx context.Context) ([]Resource, error) {
+	var result listResourcesResult
+	if err := c.call(ctx, "resources/list", struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Resources, nil
+}
+
+// ReadResource fetches the contents of a single resource by URI.
+func (c *Client) ReadResource(ctx context.Context, uri string) (json.RawMessage, error) {
+	var result json.RawMessage
+	if err := c.call(ctx, "resources/read", map[string]string{"uri": uri}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListPrompts returns the prompt templates advertised by the
+// connected server.
+func (c *Client) ListPrompts(ctx context.Context) ([]Prompt, error) {
+	var result listPromptsResult
+	if err := c.call(ctx, "prompts/list", struct{}{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Prompts, nil
+}
+
+// GetPrompt resolves a named prompt template with the given
+// arguments.
+func (c *Client) GetPrompt(ctx context.Context, name string, args map[string]string) (json.RawMessage, error) {
+	var result json.RawMessage
+	if err := c.call(ctx, "prompts/get", map[string]interface{}{
+		"name":      name,
+		"arguments": args,
+	}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CallTool invokes a tool on the connected server and returns its raw
+// result.
+func (c *Client) CallTool(ctx context.Context, name string, args map[string]interface{}) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := c.call(ctx, "tools/call", map[string]interface{}{
+		"name":      name,
+		"arguments": args,
+	}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Close tears down the underlying transport.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	t := c.transport
+	c.connected = false
+	c.mu.Unlock()
+
+	if t == nil {
+		return nil
+	}
+	return t.Close()
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// protocolVersion is the MCP protocol version this client negotiates
+// during the initialize handshake.
+const protocolVersion = "2024-11-05"
+
+// request is a JSON-RPC 2.0 request or notification. Notifications
+// omit ID.
+type request struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      *int64      `json:"id,omitempty"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC 2.0 response.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int64           `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// notification is a JSON-RPC 2.0 message with no ID, sent by the
+// server without a matching request (e.g. notifications/progress).
+type notification struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("mcp: rpc error %d: %s", e.Code, e.Message)
+}
+
+// envelope is used to sniff whether an inbound message is a response
+// (has "id" and one of "result"/"error") or a notification (no "id").
+type envelope struct {
+	ID     *int64 `json:"id"`
+	Method string `json:"method"`
+}
+
+type initializeParams struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ClientInfo      clientInfo     `json:"clientInfo"`
+}
+
+type clientInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type initializeResult struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ServerInfo      clientInfo     `json:"serverInfo"`
+}
+
+// Tool describes a tool advertised by an MCP server.
+type Tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"inputSchema,omitempty"`
+}
+
+// Resource describes a resource advertised by an MCP server.
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// Prompt describes a prompt template advertised by an MCP server.
+type Prompt struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type listToolsResult struct {
+	Tools []Tool `json:"tools"`
+}
+
+type listResourcesResult struct {
+	Resources []Resource `json:"resources"`
+}
+
+type listPromptsResult struct {
+	Prompts []Prompt `json:"prompts"`
+}
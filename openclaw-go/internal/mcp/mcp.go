@@ -2,13 +2,28 @@
 package mcp
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"sync"
+	"time"
 
-	"github.com/gorilla/websocket"
-	"go.uber.org/zap"
+	"github.com/openclaw/openclaw-go/internal/logging"
 )
 
+// TrafficRecorder observes MCP tool invocations. It is implemented by
+// internal/experimental/controlapi to power the traffic/connections
+// control API without mcp depending on it.
+type TrafficRecorder interface {
+	// BeginCall/EndCall bracket an in-flight tool call, letting the
+	// recorder track an active-requests gauge per server.
+	BeginCall(serverID string)
+	EndCall(serverID string)
+	RecordCall(serverID, tool string, argsHash string, latency time.Duration, status string, requestBytes, responseBytes int64)
+}
+
 // Config holds MCP configuration
 type Config struct {
 	Servers []ServerConfig `json:"servers"`
@@ -19,10 +34,12 @@ type ServerConfig struct {
 	ID        string            `json:"id"`
 	Name      string            `json:"name"`
 	URL       string            `json:"url"`
-	Transport string            `json:"transport"`
+	Transport string            `json:"transport"` // "stdio", "sse", or "streamable-http"
 	Enabled   bool              `json:"enabled"`
 	Auth      *AuthConfig       `json:"auth,omitempty"`
 	Env       map[string]string `json:"env,omitempty"`
+	Command   string            `json:"command,omitempty"` // stdio: executable to spawn
+	Args      []string          `json:"args,omitempty"`    // stdio: arguments to Command
 }
 
 // AuthConfig holds authentication configuration
@@ -35,17 +52,31 @@ type AuthConfig struct {
 // Manager manages MCP server connections
 type Manager struct {
 	config   Config
-	logger   *zap.Logger
+	logger   logging.Logger
 	clients  map[string]*Client
 	mu       sync.RWMutex
+	recorder TrafficRecorder
+
+	notificationHandler func(serverID, method string, params json.RawMessage)
 }
 
-// Client represents an MCP client connection
-type Client struct {
-	config     ServerConfig
-	conn       *websocket.Conn
-	connected  bool
-	mu         sync.RWMutex
+// SetTrafficRecorder installs a recorder that observes every tool
+// call routed through CallTool. Passing nil disables recording.
+func (m *Manager) SetTrafficRecorder(recorder TrafficRecorder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recorder = recorder
+}
+
+// SetNotificationHandler installs a handler invoked for every
+// server-initiated notification from any connected MCP server,
+// tagged with the originating serverID. Used by the session layer to
+// relay progress/log/resource-updated events to subscribed WebSocket
+// clients.
+func (m *Manager) SetNotificationHandler(handler func(serverID, method string, params json.RawMessage)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notificationHandler = handler
 }
 
 // LoadConfig loads MCP configuration
@@ -57,7 +88,7 @@ func LoadConfig() Config {
 }
 
 // NewManager creates a new MCP manager
-func NewManager(config Config, logger *zap.Logger) *Manager {
+func NewManager(config Config, logger logging.Logger) *Manager {
 	return &Manager{
 		config:  config,
 		logger:  logger,
@@ -65,46 +96,81 @@ func NewManager(config Config, logger *zap.Logger) *Manager {
 	}
 }
 
-// Connect connects to an MCP server
+// Connect connects to an MCP server, performing the initialize
+// handshake over the server's configured transport.
 func (m *Manager) Connect(serverID string) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	serverConfig := m.findServer(serverID)
+	if serverConfig == nil {
+		return fmt.Errorf("mcp: unknown server %q", serverID)
+	}
 
-	// Find server config
-	var serverConfig *ServerConfig
-	for i := range m.config.Servers {
-		if m.config.Servers[i].ID == serverID {
-			serverConfig = &m.config.Servers[i]
-			break
-		}
+	if !serverConfig.Enabled {
+		return fmt.Errorf("mcp: server %q is disabled", serverID)
 	}
 
+	client := newClient(*serverConfig, m.logger)
+	m.wireNotifications(client, serverID)
+	if err := client.Connect(context.Background()); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.clients[serverID] = client
+	m.mu.Unlock()
+
+	return nil
+}
+
+// ConnectWithBackoff is like Connect but retries with exponential
+// backoff until ctx is cancelled.
+func (m *Manager) ConnectWithBackoff(ctx context.Context, serverID string) error {
+	serverConfig := m.findServer(serverID)
 	if serverConfig == nil {
-		return nil // Server not found
+		return fmt.Errorf("mcp: unknown server %q", serverID)
 	}
 
 	if !serverConfig.Enabled {
-		return nil // Server disabled
+		return fmt.Errorf("mcp: server %q is disabled", serverID)
 	}
 
-	// Create client
-	client := &Client{
-		config: *serverConfig,
+	client := newClient(*serverConfig, m.logger)
+	m.wireNotifications(client, serverID)
+	if err := client.ConnectWithBackoff(ctx); err != nil {
+		return err
 	}
 
-	// Connect based on transport
-	switch serverConfig.Transport {
-	case "websocket":
-		if err := m.connectWebSocket(client); err != nil {
-			return err
-		}
-	default:
-		m.logger.Warn("Unsupported transport",
-			zap.String("transport", serverConfig.Transport),
-		)
+	m.mu.Lock()
+	m.clients[serverID] = client
+	m.mu.Unlock()
+
+	return nil
+}
+
+// wireNotifications forwards client's server-initiated notifications
+// to the Manager-wide handler, if one is installed, tagging them with
+// serverID.
+func (m *Manager) wireNotifications(client *Client, serverID string) {
+	m.mu.RLock()
+	handler := m.notificationHandler
+	m.mu.RUnlock()
+	if handler == nil {
+		return
 	}
+	client.OnNotification(func(method string, params json.RawMessage) {
+		handler(serverID, method, params)
+	})
+}
 
-	m.clients[serverID] = client
+func (m *Manager) findServer(serverID string) *ServerConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for i := range m.config.Servers {
+		if m.config.Servers[i].ID == serverID {
+			cfg := m.config.Servers[i]
+			return &cfg
+		}
+	}
 	return nil
 }
 
@@ -114,25 +180,18 @@ func (m *Manager) Disconnect(serverID string) {
 	defer m.mu.Unlock()
 
 	if client, ok := m.clients[serverID]; ok {
-		if client.conn != nil {
-			client.conn.Close()
-		}
-		client.connected = false
+		client.Close()
 		delete(m.clients, serverID)
 	}
 }
 
 // IsConnected checks if connected to a server
 func (m *Manager) IsConnected(serverID string) bool {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	if client, ok := m.clients[serverID]; ok {
-		client.mu.RLock()
-		defer client.mu.RUnlock()
-		return client.connected
+	client, ok := m.client(serverID)
+	if !ok {
+		return false
 	}
-	return false
+	return client.Connected()
 }
 
 // ListServers returns list of configured servers
@@ -140,49 +199,116 @@ func (m *Manager) ListServers() []ServerConfig {
 	return m.config.Servers
 }
 
+func (m *Manager) client(serverID string) (*Client, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	client, ok := m.clients[serverID]
+	return client, ok
+}
+
 // CallTool calls a tool on an MCP server
 func (m *Manager) CallTool(serverID string, toolName string, args map[string]interface{}) (map[string]interface{}, error) {
+	client, ok := m.client(serverID)
+	if !ok {
+		return nil, fmt.Errorf("mcp: server %q not connected", serverID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCallTimeout)
+	defer cancel()
+
 	m.mu.RLock()
-	client, ok := m.clients[serverID]
+	recorder := m.recorder
 	m.mu.RUnlock()
 
-	if !ok {
-		return nil, nil // Client not found
+	if recorder != nil {
+		recorder.BeginCall(serverID)
+		defer recorder.EndCall(serverID)
 	}
 
-	client.mu.RLock()
-	conn := client.conn
-	client.mu.RUnlock()
+	start := time.Now()
+	argsBytes, _ := json.Marshal(args)
+
+	result, err := client.CallTool(ctx, toolName, args)
 
-	if conn == nil {
-		return nil, nil // Not connected
+	if recorder != nil {
+		status := "ok"
+		if err != nil {
+			status = "error"
+		}
+		resultBytes, _ := json.Marshal(result)
+		recorder.RecordCall(serverID, toolName, hashArgs(argsBytes), time.Since(start), status, int64(len(argsBytes)), int64(len(resultBytes)))
 	}
 
-	// Send request
-	request := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"id":      1,
-		"method":  "tools/call",
-		"params": map[string]interface{}{
-			"name":      toolName,
-			"arguments": args,
-		},
+	return result, err
+}
+
+func hashArgs(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// ListTools lists the tools advertised by a connected server.
+func (m *Manager) ListTools(serverID string) ([]Tool, error) {
+	client, ok := m.client(serverID)
+	if !ok {
+		return nil, fmt.Errorf("mcp: server %q not connected", serverID)
 	}
 
-	if err := conn.WriteJSON(request); err != nil {
-		return nil, err
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCallTimeout)
+	defer cancel()
+
+	return client.ListTools(ctx)
+}
+
+// ListResources lists the resources advertised by a connected server.
+func (m *Manager) ListResources(serverID string) ([]Resource, error) {
+	client, ok := m.client(serverID)
+	if !ok {
+		return nil, fmt.Errorf("mcp: server %q not connected", serverID)
 	}
 
-	// Read response
-	var response map[string]interface{}
-	if err := conn.ReadJSON(&response); err != nil {
-		return nil, err
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCallTimeout)
+	defer cancel()
+
+	return client.ListResources(ctx)
+}
+
+// ReadResource reads a single resource by URI from a connected server.
+func (m *Manager) ReadResource(serverID, uri string) (json.RawMessage, error) {
+	client, ok := m.client(serverID)
+	if !ok {
+		return nil, fmt.Errorf("mcp: server %q not connected", serverID)
 	}
 
-	return response, nil
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCallTimeout)
+	defer cancel()
+
+	return client.ReadResource(ctx, uri)
 }
 
-func (m *Manager) connectWebSocket(client *Client) error {
-	// TODO: Implement WebSocket connection
-	return nil
+// ListPrompts lists the prompt templates advertised by a connected
+// server.
+func (m *Manager) ListPrompts(serverID string) ([]Prompt, error) {
+	client, ok := m.client(serverID)
+	if !ok {
+		return nil, fmt.Errorf("mcp: server %q not connected", serverID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCallTimeout)
+	defer cancel()
+
+	return client.ListPrompts(ctx)
+}
+
+// GetPrompt resolves a named prompt template from a connected server.
+func (m *Manager) GetPrompt(serverID, name string, args map[string]string) (json.RawMessage, error) {
+	client, ok := m.client(serverID)
+	if !ok {
+		return nil, fmt.Errorf("mcp: server %q not connected", serverID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCallTimeout)
+	defer cancel()
+
+	return client.GetPrompt(ctx, name, args)
 }
@@ -0,0 +1,11 @@
+package router
+
+// LoadConfig returns the default Loader, which currently yields an
+// empty rule set with no final outbound.
+//
+// TODO: load rules from file or environment.
+func LoadConfig() Loader {
+	return func() ([]Rule, string, error) {
+		return nil, "", nil
+	}
+}
@@ -0,0 +1,132 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ToolGlob matches req.Tool against a glob pattern where "*" matches
+// any run of characters (no "?" or character classes - tool names
+// don't need anything fancier than namespace prefixes like
+// "github.*").
+type ToolGlob struct {
+	Pattern string
+}
+
+func (m ToolGlob) Match(_ context.Context, req ToolRequest) bool {
+	return globMatch(m.Pattern, req.Tool)
+}
+
+func globMatch(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(s, part)
+		if idx < 0 {
+			return false
+		}
+		s = s[idx+len(part):]
+	}
+
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
+
+// ArgPredicate matches a dot-separated path into req.Args (e.g.
+// "user.role") against a value using the given operator: "eq", "ne",
+// "contains", or "exists".
+type ArgPredicate struct {
+	Path  string
+	Op    string
+	Value interface{}
+}
+
+func (m ArgPredicate) Match(_ context.Context, req ToolRequest) bool {
+	val, ok := lookupArgPath(req.Args, m.Path)
+
+	switch m.Op {
+	case "exists":
+		return ok
+	case "eq":
+		return ok && fmt.Sprint(val) == fmt.Sprint(m.Value)
+	case "ne":
+		return !ok || fmt.Sprint(val) != fmt.Sprint(m.Value)
+	case "contains":
+		s, isStr := val.(string)
+		needle := fmt.Sprint(m.Value)
+		return ok && isStr && strings.Contains(s, needle)
+	default:
+		return false
+	}
+}
+
+// lookupArgPath walks a dotted path ("a.b.c") through nested
+// map[string]interface{} values, as produced by decoding a tool call's
+// JSON arguments.
+func lookupArgPath(args map[string]interface{}, path string) (interface{}, bool) {
+	segments := strings.Split(path, ".")
+
+	var current interface{} = args
+	for _, seg := range segments {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[seg]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// Caller matches req.CallerID against an allow-list of identities.
+type Caller struct {
+	Allow []string
+}
+
+func (m Caller) Match(_ context.Context, req ToolRequest) bool {
+	for _, id := range m.Allow {
+		if id == req.CallerID {
+			return true
+		}
+	}
+	return false
+}
+
+// TimeOfDay matches when req.Time's local clock time falls within
+// [Start, End), both given as "HH:MM". A window that wraps past
+// midnight (Start > End) is supported.
+type TimeOfDay struct {
+	Start string
+	End   string
+}
+
+func (m TimeOfDay) Match(_ context.Context, req ToolRequest) bool {
+	start, err := time.Parse("15:04", m.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", m.End)
+	if err != nil {
+		return false
+	}
+
+	t := req.Time
+	clock := time.Date(0, 1, 1, t.Hour(), t.Minute(), 0, 0, time.UTC)
+
+	if start.Before(end) || start.Equal(end) {
+		return !clock.Before(start) && clock.Before(end)
+	}
+	// window wraps past midnight, e.g. 22:00-06:00
+	return !clock.Before(start) || clock.Before(end)
+}
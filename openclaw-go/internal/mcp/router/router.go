@@ -0,0 +1,164 @@
+// Package router implements a pluggable, rule-based dispatcher for
+// MCP tool calls, inspired by sing-box's Rule/Router split: an
+// ordered list of rules is matched against an inbound tool call, and
+// the first rule whose matchers all agree picks the outbound MCP
+// server. A "final" rule provides the default outbound when nothing
+// else matches.
+package router
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/openclaw/openclaw-go/internal/logging"
+)
+
+// ErrNoMatch is returned by Router.Match when no rule matched and no
+// final outbound is configured.
+var ErrNoMatch = errors.New("router: no rule matched and no final outbound configured")
+
+// ToolRequest is the routing-relevant view of an inbound tool call.
+type ToolRequest struct {
+	Tool     string
+	Args     map[string]interface{}
+	CallerID string
+	Time     time.Time
+}
+
+// Matcher decides whether a single predicate of a Rule holds for req.
+type Matcher interface {
+	Match(ctx context.Context, req ToolRequest) bool
+}
+
+// Rule is an ordered set of matchers that must all hold for the rule
+// to select its Outbound server.
+type Rule struct {
+	Name     string
+	Matchers []Matcher
+	Outbound string
+}
+
+// Match reports whether every matcher in the rule holds for req. A
+// rule with no matchers never matches (use Loader's final outbound
+// for a catch-all instead).
+func (r Rule) Match(ctx context.Context, req ToolRequest) bool {
+	if len(r.Matchers) == 0 {
+		return false
+	}
+	for _, m := range r.Matchers {
+		if !m.Match(ctx, req) {
+			return false
+		}
+	}
+	return true
+}
+
+// Loader produces the current rule set and default outbound. It is
+// invoked once at construction and again on every Reload.
+type Loader func() (rules []Rule, final string, err error)
+
+// Router dispatches tool calls to an MCP server ID by matching them
+// against an ordered rule set, with hot-reload support.
+type Router struct {
+	logger logging.Logger
+	loader Loader
+
+	mu    sync.RWMutex
+	rules []Rule
+	final string
+
+	stopReload context.CancelFunc
+}
+
+// New builds a Router and performs the initial load. logger is
+// typically the "mcp" logger, and New names its child "mcp.router".
+func New(logger logging.Logger, loader Loader) (*Router, error) {
+	r := &Router{
+		logger: logger.Named("router"),
+		loader: loader,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload re-invokes the Loader and swaps in the new rule set
+// atomically. Existing in-flight Match calls are unaffected.
+func (r *Router) Reload() error {
+	rules, final, err := r.loader()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.rules = rules
+	r.final = final
+	r.mu.Unlock()
+
+	r.logger.Info("router rules reloaded", "rules", len(rules), "final", final)
+	return nil
+}
+
+// Match returns the outbound server ID for req: the Outbound of the
+// first rule that fully matches, or the configured final outbound if
+// no rule matches. rule is the matched rule, or nil when the final
+// outbound is used. When neither applies, outbound is empty, rule is
+// nil and err is ErrNoMatch.
+func (r *Router) Match(ctx context.Context, req ToolRequest) (outbound string, rule *Rule, err error) {
+	r.mu.RLock()
+	rules := r.rules
+	final := r.final
+	r.mu.RUnlock()
+
+	for i := range rules {
+		if rules[i].Match(ctx, req) {
+			matched := rules[i]
+			return matched.Outbound, &matched, nil
+		}
+	}
+
+	if final != "" {
+		return final, nil, nil
+	}
+
+	return "", nil, ErrNoMatch
+}
+
+// WatchReloadSignals spawns a goroutine that calls Reload every time
+// the process receives SIGHUP, until ctx is cancelled. Reload errors
+// are logged but otherwise leave the previous rule set in place.
+func (r *Router) WatchReloadSignals(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	r.stopReload = cancel
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sig)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sig:
+				if err := r.Reload(); err != nil {
+					r.logger.Error("router reload failed", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// StopWatchingReloadSignals stops the goroutine started by
+// WatchReloadSignals, if any.
+func (r *Router) StopWatchingReloadSignals() {
+	if r.stopReload != nil {
+		r.stopReload()
+	}
+}
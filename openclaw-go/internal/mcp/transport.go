@@ -0,0 +1,39 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+)
+
+// transport abstracts the wire-level framing for a single MCP server
+// connection. Client owns JSON-RPC semantics (ids, pending requests,
+// notifications); a transport just moves raw message bytes.
+type transport interface {
+	// Start establishes the underlying connection (subprocess, HTTP
+	// stream, ...) and begins delivering inbound messages on the
+	// channel returned by Inbound. It must not block past the point
+	// the connection is established.
+	Start(ctx context.Context) error
+	// Send writes a single JSON-RPC message.
+	Send(ctx context.Context, msg []byte) error
+	// Inbound returns the channel inbound messages are delivered on.
+	// It is closed when the connection is lost or Close is called.
+	Inbound() <-chan []byte
+	// Close tears down the underlying connection.
+	Close() error
+}
+
+// newTransport builds the transport for a server's configured
+// Transport kind.
+func newTransport(cfg ServerConfig) (transport, error) {
+	switch cfg.Transport {
+	case "stdio":
+		return newStdioTransport(cfg), nil
+	case "sse":
+		return newSSETransport(cfg), nil
+	case "streamable-http":
+		return newStreamableHTTPTransport(cfg), nil
+	default:
+		return nil, fmt.Errorf("mcp: unsupported transport %q", cfg.Transport)
+	}
+}
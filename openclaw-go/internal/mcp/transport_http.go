@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// streamableHTTPTransport implements the MCP "streamable-http"
+// transport: a single endpoint that accepts POSTed JSON-RPC messages
+// and may reply with either a plain `application/json` body or a
+// chunked `text/event-stream` of one or more messages.
+type streamableHTTPTransport struct {
+	cfg ServerConfig
+
+	client *http.Client
+	inbox  chan []byte
+
+	mu        sync.Mutex
+	sessionID string
+}
+
+func newStreamableHTTPTransport(cfg ServerConfig) *streamableHTTPTransport {
+	return &streamableHTTPTransport{
+		cfg:    cfg,
+		client: &http.Client{},
+		inbox:  make(chan []byte, 64),
+	}
+}
+
+func (t *streamableHTTPTransport) Start(ctx context.Context) error {
+	// Nothing to dial up front: every request/response pair is its
+	// own HTTP round trip over the single endpoint.
+	return nil
+}
+
+func (t *streamableHTTPTransport) Send(ctx context.Context, msg []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.cfg.URL, bytes.NewReader(msg))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	t.mu.Lock()
+	sessionID := t.sessionID
+	t.mu.Unlock()
+	if sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", sessionID)
+	}
+
+	applyAuth(req, t.cfg.Auth)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("mcp: streamable-http: unexpected status %s", resp.Status)
+	}
+
+	if sid := resp.Header.Get("Mcp-Session-Id"); sid != "" {
+		t.mu.Lock()
+		t.sessionID = sid
+		t.mu.Unlock()
+	}
+
+	switch contentType(resp.Header.Get("Content-Type")) {
+	case "text/event-stream":
+		t.consumeChunkedStream(resp)
+	case "application/json":
+		body, err := io.ReadAll(resp.Body)
+		if err == nil && len(body) > 0 {
+			t.inbox <- body
+		}
+	}
+
+	return nil
+}
+
+// consumeChunkedStream reads `data:` events off a chunked
+// text/event-stream response body, forwarding each as a separate
+// inbound JSON-RPC message.
+func (t *streamableHTTPTransport) consumeChunkedStream(resp *http.Response) {
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				msg := make([]byte, data.Len())
+				copy(msg, data.Bytes())
+				t.inbox <- msg
+				data.Reset()
+			}
+		case len(line) >= 5 && line[:5] == "data:":
+			data.WriteString(line[5:])
+		}
+	}
+}
+
+func contentType(header string) string {
+	for i := 0; i < len(header); i++ {
+		if header[i] == ';' {
+			return header[:i]
+		}
+	}
+	return header
+}
+
+func (t *streamableHTTPTransport) Inbound() <-chan []byte {
+	return t.inbox
+}
+
+func (t *streamableHTTPTransport) Close() error {
+	return nil
+}
+
+// applyAuth attaches the configured AuthConfig to an outbound HTTP
+// request, shared by the sse and streamable-http transports.
+func applyAuth(req *http.Request, auth *AuthConfig) {
+	if auth == nil {
+		return
+	}
+	switch auth.Type {
+	case "bearer":
+		req.Header.Set("Authorization", "Bearer "+auth.Token)
+	case "apikey":
+		req.Header.Set("X-API-Key", auth.APIKey)
+	}
+}
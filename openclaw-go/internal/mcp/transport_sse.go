@@ -0,0 +1,204 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// endpointTimeout bounds how long Start waits for the stream's
+// "endpoint" event before giving up, so a server that never sends one
+// fails fast instead of silently POSTing to the wrong URL.
+const endpointTimeout = 10 * time.Second
+
+// sseTransport implements the MCP "sse" transport: outbound messages
+// are POSTed to the message endpoint named by the stream's "endpoint"
+// event (resolved against the server URL), and inbound messages (responses + server-initiated notifications)
+// arrive as `data:` events on a long-lived `text/event-stream` GET.
+type sseTransport struct {
+	cfg ServerConfig
+
+	client *http.Client
+
+	mu         sync.Mutex
+	cancel     context.CancelFunc
+	respBody   io.Closer
+	inbox      chan []byte
+	messageURL string
+
+	endpointReady chan struct{}
+	endpointOnce  sync.Once
+}
+
+func newSSETransport(cfg ServerConfig) *sseTransport {
+	return &sseTransport{
+		cfg:           cfg,
+		client:        &http.Client{},
+		inbox:         make(chan []byte, 64),
+		endpointReady: make(chan struct{}),
+	}
+}
+
+func (t *sseTransport) Start(ctx context.Context) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+
+	req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, t.cfg.URL, nil)
+	if err != nil {
+		cancel()
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	t.applyAuth(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("mcp: sse connect: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		cancel()
+		resp.Body.Close()
+		return fmt.Errorf("mcp: sse connect: unexpected status %s", resp.Status)
+	}
+
+	t.mu.Lock()
+	t.cancel = cancel
+	t.respBody = resp.Body
+	t.mu.Unlock()
+
+	go t.readLoop(resp.Body)
+
+	// Block until the stream's "endpoint" event tells us where to POST
+	// outbound messages - Connect sends "initialize" right after Start
+	// returns, and there is no correct URL to fall back to.
+	select {
+	case <-t.endpointReady:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	case <-time.After(endpointTimeout):
+		cancel()
+		return fmt.Errorf("mcp: sse connect: timed out waiting for endpoint event")
+	}
+}
+
+// readLoop consumes the SSE stream. The first "endpoint" event gives
+// us the URL to POST outbound messages to; subsequent "message"
+// events carry JSON-RPC payloads in their data field.
+func (t *sseTransport) readLoop(body io.Reader) {
+	defer close(t.inbox)
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var event string
+	var data bytes.Buffer
+
+	flush := func() {
+		if data.Len() == 0 {
+			event = ""
+			return
+		}
+		payload := bytes.TrimSuffix(data.Bytes(), []byte("\n"))
+
+		switch event {
+		case "endpoint":
+			t.mu.Lock()
+			t.messageURL = resolveEndpoint(t.cfg.URL, string(payload))
+			t.mu.Unlock()
+			t.endpointOnce.Do(func() { close(t.endpointReady) })
+		default:
+			msg := make([]byte, len(payload))
+			copy(msg, payload)
+			t.inbox <- msg
+		}
+
+		event = ""
+		data.Reset()
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimPrefix(line, "data:"))
+			data.WriteByte('\n')
+		}
+	}
+	flush()
+}
+
+// resolveEndpoint turns the relative path from an "endpoint" SSE
+// event into an absolute URL against the original server URL.
+func resolveEndpoint(base, endpoint string) string {
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return endpoint
+	}
+	idx := strings.Index(base[strings.Index(base, "://")+3:], "/")
+	if idx < 0 {
+		return base + endpoint
+	}
+	origin := base[:strings.Index(base, "://")+3+idx]
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+	return origin + endpoint
+}
+
+func (t *sseTransport) Send(ctx context.Context, msg []byte) error {
+	t.mu.Lock()
+	url := t.messageURL
+	t.mu.Unlock()
+	if url == "" {
+		url = t.cfg.URL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(msg))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	t.applyAuth(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("mcp: sse post message: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (t *sseTransport) applyAuth(req *http.Request) {
+	applyAuth(req, t.cfg.Auth)
+}
+
+func (t *sseTransport) Inbound() <-chan []byte {
+	return t.inbox
+}
+
+func (t *sseTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cancel != nil {
+		t.cancel()
+	}
+	if t.respBody != nil {
+		return t.respBody.Close()
+	}
+	return nil
+}
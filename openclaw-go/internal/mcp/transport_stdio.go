@@ -0,0 +1,167 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// stdioTransport spawns an MCP server as a subprocess and frames
+// JSON-RPC messages over its stdin/stdout using LSP-style
+// "Content-Length" headers.
+type stdioTransport struct {
+	cfg ServerConfig
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	inbox  chan []byte
+	closed chan struct{}
+
+	// writeMu serializes Send: Client multiplexes concurrent requests
+	// over one transport, and a header+body write must not interleave
+	// with another goroutine's on the wire.
+	writeMu sync.Mutex
+}
+
+func newStdioTransport(cfg ServerConfig) *stdioTransport {
+	return &stdioTransport{
+		cfg:    cfg,
+		inbox:  make(chan []byte, 64),
+		closed: make(chan struct{}),
+	}
+}
+
+func (t *stdioTransport) Start(ctx context.Context) error {
+	name, args := t.cfg.Command, t.cfg.Args
+	if name == "" {
+		return fmt.Errorf("mcp: stdio transport for %q requires a command", t.cfg.ID)
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Env = os.Environ()
+	for k, v := range t.cfg.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("mcp: stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("mcp: stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("mcp: starting %q: %w", name, err)
+	}
+
+	t.mu.Lock()
+	t.cmd = cmd
+	t.stdin = stdin
+	t.mu.Unlock()
+
+	go t.readLoop(bufio.NewReader(stdout))
+
+	return nil
+}
+
+// readLoop parses Content-Length framed messages off the subprocess's
+// stdout, identical to the framing used by LSP servers.
+func (t *stdioTransport) readLoop(r *bufio.Reader) {
+	defer close(t.inbox)
+
+	for {
+		length, err := readContentLength(r)
+		if err != nil {
+			return
+		}
+
+		body := make([]byte, length)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return
+		}
+
+		select {
+		case t.inbox <- body:
+		case <-t.closed:
+			return
+		}
+	}
+}
+
+func readContentLength(r *bufio.Reader) (int, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break // blank line terminates the header block
+		}
+		if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return 0, fmt.Errorf("mcp: bad Content-Length header %q: %w", value, err)
+			}
+			length = n
+		}
+	}
+	if length < 0 {
+		return 0, fmt.Errorf("mcp: message missing Content-Length header")
+	}
+	return length, nil
+}
+
+func (t *stdioTransport) Send(ctx context.Context, msg []byte) error {
+	t.mu.Lock()
+	stdin := t.stdin
+	t.mu.Unlock()
+	if stdin == nil {
+		return fmt.Errorf("mcp: stdio transport not started")
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+
+	frame := fmt.Sprintf("Content-Length: %d\r\n\r\n", len(msg))
+	if _, err := io.WriteString(stdin, frame); err != nil {
+		return err
+	}
+	_, err := stdin.Write(msg)
+	return err
+}
+
+func (t *stdioTransport) Inbound() <-chan []byte {
+	return t.inbox
+}
+
+func (t *stdioTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	select {
+	case <-t.closed:
+		return nil
+	default:
+		close(t.closed)
+	}
+
+	if t.stdin != nil {
+		t.stdin.Close()
+	}
+	if t.cmd != nil && t.cmd.Process != nil {
+		return t.cmd.Process.Kill()
+	}
+	return nil
+}
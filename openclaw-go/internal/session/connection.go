@@ -0,0 +1,282 @@
+package session
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/openclaw/openclaw-go/internal/logging"
+)
+
+const methodAck = "control.ack"
+
+// queuedFrame is one outbound wire frame, numbered so a resumed
+// session can tell the client which frames it has already delivered.
+type queuedFrame struct {
+	seq  uint64
+	data []byte
+}
+
+// Session is one logical client connection: its ID and resumption
+// token persist across reconnects even though the underlying
+// *websocket.Conn does not. Exactly one goroutine (run) owns the
+// connection at a time; Notify and the dispatcher may be called
+// concurrently from elsewhere.
+type Session struct {
+	id     string
+	token  string
+	caller string
+
+	logger logging.Logger
+
+	sendCh chan queuedFrame
+
+	mu         sync.Mutex
+	conn       *websocket.Conn
+	stopWrite  chan struct{}
+	nextSeq    uint64
+	ackSeq     uint64
+	replay     []queuedFrame
+	resumed    bool
+	detachedAt time.Time
+	subs       map[string]map[string]struct{} // serverID -> uri set
+}
+
+// ID is the durable session identifier, stable across reconnects.
+func (s *Session) ID() string { return s.id }
+
+// Token is the signed resumption token clients should pass back as
+// ?resume=<token> to reattach to this session.
+func (s *Session) Token() string { return s.token }
+
+// CallerID is the mTLS-verified identity of the connection that
+// created this session, or "" if mTLS wasn't used.
+func (s *Session) CallerID() string { return s.caller }
+
+func (s *Session) bind(conn *websocket.Conn) {
+	s.mu.Lock()
+	s.conn = conn
+	s.stopWrite = make(chan struct{})
+	s.detachedAt = time.Time{}
+	s.mu.Unlock()
+
+	conn.SetReadDeadline(time.Now().Add(readDeadline))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(readDeadline))
+		return nil
+	})
+}
+
+func (s *Session) detach() {
+	s.mu.Lock()
+	s.conn = nil
+	s.detachedAt = time.Now()
+	s.mu.Unlock()
+}
+
+func (s *Session) isStale(grace time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.detachedAt.IsZero() && time.Since(s.detachedAt) > grace
+}
+
+// run drives the session's read and write pumps against its
+// currently-bound connection until the connection errors or closes,
+// replaying any unacked frames from a previous connection first.
+func (s *Session) run(m *Manager) {
+	done := make(chan struct{})
+	go func() {
+		s.writePump()
+		close(done)
+	}()
+
+	s.readPump(m)
+
+	s.mu.Lock()
+	stopWrite := s.stopWrite
+	s.mu.Unlock()
+	close(stopWrite)
+	<-done
+}
+
+// writePump is the sole writer of s.conn for the lifetime of one
+// bind: gorilla's websocket.Conn forbids concurrent writes, so
+// replaying unacked frames, draining sendCh, and sending heartbeat
+// pings all happen from this one goroutine.
+func (s *Session) writePump() {
+	s.mu.Lock()
+	conn := s.conn
+	stopWrite := s.stopWrite
+	pending := make([]queuedFrame, len(s.replay))
+	copy(pending, s.replay)
+	s.mu.Unlock()
+
+	for _, f := range pending {
+		conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+		if err := conn.WriteMessage(websocket.TextMessage, f.data); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopWrite:
+			return
+		case qf := <-s.sendCh:
+			s.recordReplay(qf)
+			conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+			if err := conn.WriteMessage(websocket.TextMessage, qf.data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *Session) readPump(m *Manager) {
+	for {
+		s.mu.Lock()
+		conn := s.conn
+		s.mu.Unlock()
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		s.handleFrame(m, data)
+	}
+}
+
+// handleFrame decodes one inbound frame and either handles it inline
+// (control.ack, which must stay ordered with the read loop) or hands
+// it off to its own goroutine. Dispatch (m.dispatch) can block for as
+// long as defaultCallTimeout on a slow mcp.call_tool, and readPump
+// must get back to conn.ReadMessage() promptly - both to pick up the
+// next frame and to keep refreshing the read deadline via the pong
+// handler - so a slow call must never stall it.
+func (s *Session) handleFrame(m *Manager, data []byte) {
+	var req request
+	if err := json.Unmarshal(data, &req); err != nil {
+		seq := s.nextSeqNo()
+		s.enqueueBlocking(seq, newErrorResponse(nil, errParseError, "invalid JSON", seq))
+		return
+	}
+
+	if req.Method == methodAck {
+		s.handleAck(req.Params)
+		return
+	}
+
+	go s.dispatchAndRespond(m, req)
+}
+
+// dispatchAndRespond runs req against m.dispatch and queues its
+// response, if any. Always called on its own goroutine (see
+// handleFrame) so a slow call can't block the rest of the connection.
+func (s *Session) dispatchAndRespond(m *Manager, req request) {
+	result, rpcErr := m.dispatch(s, req)
+	if len(req.ID) == 0 {
+		return // notification from the client: JSON-RPC has no response
+	}
+	if rpcErr != nil {
+		seq := s.nextSeqNo()
+		s.enqueueBlocking(seq, newErrorResponse(req.ID, rpcErr.Code, rpcErr.Message, seq))
+		return
+	}
+	seq := s.nextSeqNo()
+	s.enqueueBlocking(seq, newResponse(req.ID, result, seq))
+}
+
+func (s *Session) handleAck(params json.RawMessage) {
+	var body struct {
+		Seq uint64 `json:"seq"`
+	}
+	if err := json.Unmarshal(params, &body); err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if body.Seq > s.ackSeq {
+		s.ackSeq = body.Seq
+	}
+	kept := s.replay[:0]
+	for _, f := range s.replay {
+		if f.seq > s.ackSeq {
+			kept = append(kept, f)
+		}
+	}
+	s.replay = kept
+}
+
+func (s *Session) recordReplay(qf queuedFrame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replay = append(s.replay, qf)
+	if len(s.replay) > replayBufferSize {
+		s.replay = s.replay[len(s.replay)-replayBufferSize:]
+	}
+}
+
+func (s *Session) nextSeqNo() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextSeq++
+	return s.nextSeq
+}
+
+// enqueueBlocking queues a response frame under the given seq
+// (already stamped into data by the caller), blocking if the send
+// queue is full rather than dropping it - a response must eventually
+// reach the client that asked for it.
+func (s *Session) enqueueBlocking(seq uint64, data []byte) {
+	s.sendCh <- queuedFrame{seq: seq, data: data}
+}
+
+// Notify queues a server-initiated notification. Unlike responses,
+// notifications drop the oldest queued frame to make room rather than
+// block, since a stale progress/log update is better discarded than
+// allowed to stall the session.
+func (s *Session) Notify(method string, params interface{}) {
+	seq := s.nextSeqNo()
+	qf := queuedFrame{seq: seq, data: newNotification(method, params, seq)}
+	select {
+	case s.sendCh <- qf:
+	default:
+		select {
+		case <-s.sendCh:
+		default:
+		}
+		select {
+		case s.sendCh <- qf:
+		default:
+		}
+	}
+}
+
+func (s *Session) subscribe(serverID, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subs == nil {
+		s.subs = make(map[string]map[string]struct{})
+	}
+	if s.subs[serverID] == nil {
+		s.subs[serverID] = make(map[string]struct{})
+	}
+	s.subs[serverID][uri] = struct{}{}
+}
+
+func (s *Session) isSubscribed(serverID, uri string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.subs[serverID][uri]
+	return ok
+}
@@ -0,0 +1,95 @@
+package session
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// dispatch runs a single JSON-RPC method call from s against m.mcp,
+// returning either a result (to be wrapped in a response) or an
+// rpcError.
+func (m *Manager) dispatch(s *Session, req request) (interface{}, *rpcError) {
+	switch req.Method {
+	case MethodListServers:
+		return listServersResult{Servers: m.mcp.ListServers()}, nil
+
+	case MethodCallTool:
+		var params callToolParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: errInvalidParams, Message: "mcp.call_tool: " + err.Error()}
+		}
+		result, err := m.mcp.CallTool(params.ServerID, params.Tool, params.Args)
+		if err != nil {
+			return nil, &rpcError{Code: errInternalError, Message: err.Error()}
+		}
+		return result, nil
+
+	case MethodSubscribeResource:
+		var params subscribeResourceParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: errInvalidParams, Message: "mcp.subscribe_resource: " + err.Error()}
+		}
+		if _, err := m.mcp.ReadResource(params.ServerID, params.URI); err != nil {
+			return nil, &rpcError{Code: errInternalError, Message: err.Error()}
+		}
+		s.subscribe(params.ServerID, params.URI)
+		return subscribeResourceResult{Subscribed: true, ServerID: params.ServerID, URI: params.URI}, nil
+
+	case MethodPing:
+		return pingResult{Pong: true, SessionID: s.id}, nil
+
+	default:
+		return nil, &rpcError{Code: errMethodNotFound, Message: "unknown method " + req.Method}
+	}
+}
+
+// routeNotification is installed as the mcp.Manager's notification
+// handler, so every connected MCP server's push traffic gets relayed
+// to the session clients that care about it.
+func (m *Manager) routeNotification(serverID, method string, params json.RawMessage) {
+	switch {
+	case strings.Contains(method, "resources/updated"):
+		var body struct {
+			URI string `json:"uri"`
+		}
+		_ = json.Unmarshal(params, &body)
+		m.notifySubscribers(serverID, body.URI, NotificationResourceUpdated, notificationPayload(serverID, params))
+
+	case strings.Contains(method, "progress"):
+		m.broadcast(NotificationProgress, notificationPayload(serverID, params))
+
+	default:
+		m.broadcast(NotificationLog, notificationPayload(serverID, params))
+	}
+}
+
+func notificationPayload(serverID string, params json.RawMessage) interface{} {
+	return struct {
+		ServerID string          `json:"server_id"`
+		Params   json.RawMessage `json:"params,omitempty"`
+	}{ServerID: serverID, Params: params}
+}
+
+func (m *Manager) broadcast(method string, params interface{}) {
+	for _, sess := range m.liveSessions() {
+		sess.Notify(method, params)
+	}
+}
+
+func (m *Manager) notifySubscribers(serverID, uri, method string, params interface{}) {
+	for _, sess := range m.liveSessions() {
+		if sess.isSubscribed(serverID, uri) {
+			sess.Notify(method, params)
+		}
+	}
+}
+
+func (m *Manager) liveSessions() []*Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, sess := range m.sessions {
+		sessions = append(sessions, sess)
+	}
+	return sessions
+}
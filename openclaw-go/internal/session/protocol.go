@@ -0,0 +1,136 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ProtocolVersion is negotiated as the WebSocket subprotocol
+// (Sec-WebSocket-Protocol) for the session-oriented JSON-RPC API.
+const ProtocolVersion = "openclaw.v1"
+
+// Methods a client frame's "method" may name.
+const (
+	MethodListServers       = "mcp.list_servers"
+	MethodCallTool          = "mcp.call_tool"
+	MethodSubscribeResource = "mcp.subscribe_resource"
+	MethodPing              = "control.ping"
+)
+
+// Notification methods the server may push without a matching request.
+const (
+	NotificationProgress        = "notifications/progress"
+	NotificationLog             = "notifications/log"
+	NotificationResourceUpdated = "notifications/resource_updated"
+
+	// NotificationSessionReady is sent once, immediately after a fresh
+	// (non-resumed) session is bound, carrying the ID and resumption
+	// token a later reconnect should pass as ?resume=.
+	NotificationSessionReady = "session.ready"
+)
+
+type sessionReadyParams struct {
+	SessionID   string `json:"session_id"`
+	ResumeToken string `json:"resume_token"`
+}
+
+// request is an inbound JSON-RPC 2.0 request. ID is preserved as raw
+// JSON (rather than decoded) since the JSON-RPC spec allows it to be
+// a string, number, or null, and a response must echo it verbatim.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is an outbound JSON-RPC 2.0 response, correlated to a
+// request by ID. Seq is the server's per-session frame sequence
+// number (see Session.nextSeqNo), which the client must echo back in
+// a control.ack so the replay buffer knows what it can drop.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	Seq     uint64          `json:"seq"`
+}
+
+// serverNotification is an outbound JSON-RPC 2.0 message with no ID,
+// sent without a matching client request. Seq is the same per-session
+// sequence number described on response.
+type serverNotification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+	Seq     uint64      `json:"seq"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("session: rpc error %d: %s", e.Code, e.Message)
+}
+
+// Standard JSON-RPC 2.0 error codes used by the dispatcher.
+const (
+	errParseError     = -32700
+	errInvalidRequest = -32600
+	errMethodNotFound = -32601
+	errInvalidParams  = -32602
+	errInternalError  = -32603
+)
+
+// newResponse, newErrorResponse and newNotification each take the
+// frame's seq (assigned by Session.nextSeqNo before the frame is
+// built) and stamp it into the wire envelope, so the client has
+// something to name in a later control.ack.
+
+func newResponse(id json.RawMessage, result interface{}, seq uint64) []byte {
+	b, err := json.Marshal(response{JSONRPC: "2.0", ID: id, Result: result, Seq: seq})
+	if err != nil {
+		return newErrorResponse(id, errInternalError, err.Error(), seq)
+	}
+	return b
+}
+
+func newErrorResponse(id json.RawMessage, code int, message string, seq uint64) []byte {
+	b, _ := json.Marshal(response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}, Seq: seq})
+	return b
+}
+
+func newNotification(method string, params interface{}, seq uint64) []byte {
+	b, _ := json.Marshal(serverNotification{JSONRPC: "2.0", Method: method, Params: params, Seq: seq})
+	return b
+}
+
+// listServersResult is the result of mcp.list_servers, which ignores
+// params.
+type listServersResult struct {
+	Servers interface{} `json:"servers"`
+}
+
+type callToolParams struct {
+	ServerID string                 `json:"server_id"`
+	Tool     string                 `json:"tool"`
+	Args     map[string]interface{} `json:"args"`
+}
+
+type subscribeResourceParams struct {
+	ServerID string `json:"server_id"`
+	URI      string `json:"uri"`
+}
+
+type subscribeResourceResult struct {
+	Subscribed bool   `json:"subscribed"`
+	ServerID   string `json:"server_id"`
+	URI        string `json:"uri"`
+}
+
+type pingResult struct {
+	Pong      bool   `json:"pong"`
+	SessionID string `json:"session_id"`
+}
@@ -0,0 +1,186 @@
+// Package session implements a session-oriented JSON-RPC protocol for
+// the gateway's WebSocket endpoint: each client gets a durable session
+// ID and a signed resumption token, independent of any one
+// *websocket.Conn, so a reconnect can pick back up where a dropped
+// connection left off instead of starting over.
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/openclaw/openclaw-go/internal/logging"
+	"github.com/openclaw/openclaw-go/internal/mcp"
+)
+
+const (
+	heartbeatInterval = 20 * time.Second
+	readDeadline      = 60 * time.Second
+
+	defaultQueueCapacity = 64
+	replayBufferSize     = 256
+
+	// resumeGracePeriod is how long a detached session (its
+	// connection closed, but not explicitly ended) stays resumable.
+	resumeGracePeriod = 5 * time.Minute
+)
+
+// Config controls session behavior.
+type Config struct {
+	// Secret signs resumption tokens with HMAC-SHA256. If empty, a
+	// random secret is generated, which means tokens stop validating
+	// across a process restart.
+	Secret []byte
+	// QueueCapacity bounds each session's outbound send queue.
+	// Defaults to 64.
+	QueueCapacity int
+}
+
+// Manager owns every live and recently-detached Session, and
+// dispatches inbound JSON-RPC frames against an mcp.Manager.
+type Manager struct {
+	mcp    *mcp.Manager
+	logger logging.Logger
+	config Config
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewManager creates a Manager. logger is typically the gateway's
+// "gateway.ws" logger.
+func NewManager(mcpManager *mcp.Manager, logger logging.Logger, config Config) *Manager {
+	if config.QueueCapacity <= 0 {
+		config.QueueCapacity = defaultQueueCapacity
+	}
+	if len(config.Secret) == 0 {
+		config.Secret = randomSecret()
+	}
+	m := &Manager{
+		mcp:      mcpManager,
+		logger:   logger,
+		config:   config,
+		sessions: make(map[string]*Session),
+	}
+	mcpManager.SetNotificationHandler(m.routeNotification)
+	return m
+}
+
+func randomSecret() []byte {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b) // crypto/rand.Read never returns a short read on the platforms we ship
+	return b
+}
+
+// signToken returns a resumption token binding to sessionID.
+func (m *Manager) signToken(sessionID string) string {
+	mac := hmac.New(sha256.New, m.config.Secret)
+	mac.Write([]byte(sessionID))
+	return sessionID + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyToken extracts the session ID from a resumption token,
+// rejecting it if the signature doesn't match what signToken would
+// have produced.
+func (m *Manager) verifyToken(token string) (string, bool) {
+	idx := strings.LastIndex(token, ".")
+	if idx < 0 {
+		return "", false
+	}
+	sessionID := token[:idx]
+	if !hmac.Equal([]byte(m.signToken(sessionID)), []byte(token)) {
+		return "", false
+	}
+	return sessionID, true
+}
+
+// Accept binds conn to a session - resuming the one named by
+// resumeToken if it is still within its grace period, or else
+// minting a new one - and runs its read/write pumps until conn
+// closes. callerIdentity is the mTLS-verified caller, if any.
+func (m *Manager) Accept(conn *websocket.Conn, resumeToken, callerIdentity string) {
+	m.reap()
+
+	sess := m.lookupResumable(resumeToken)
+	isNew := sess == nil
+	if isNew {
+		sess = m.newSession(callerIdentity)
+	}
+
+	m.mu.Lock()
+	m.sessions[sess.id] = sess
+	m.mu.Unlock()
+
+	sess.logger = m.logger.With("session_id", sess.id)
+	sess.bind(conn)
+	sess.logger.Info("session attached", "resumed", !isNew, "caller_id", sess.caller)
+
+	if isNew {
+		// A freshly minted session: tell the client its ID and
+		// resumption token so a later reconnect can pass ?resume=.
+		sess.Notify(NotificationSessionReady, sessionReadyParams{
+			SessionID:   sess.id,
+			ResumeToken: sess.token,
+		})
+	}
+
+	sess.run(m)
+
+	sess.detach()
+	sess.logger.Info("session detached")
+}
+
+func (m *Manager) lookupResumable(token string) *Session {
+	if token == "" {
+		return nil
+	}
+	sessionID, ok := m.verifyToken(token)
+	if !ok {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sess, ok := m.sessions[sessionID]
+	if !ok {
+		return nil
+	}
+	sess.resumed = true
+	return sess
+}
+
+func (m *Manager) newSession(callerIdentity string) *Session {
+	id := newSessionID()
+	return &Session{
+		id:     id,
+		token:  m.signToken(id),
+		caller: callerIdentity,
+		sendCh: make(chan queuedFrame, m.config.QueueCapacity),
+	}
+}
+
+// reap drops sessions that have been detached for longer than
+// resumeGracePeriod, so the map doesn't grow unbounded.
+func (m *Manager) reap() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, sess := range m.sessions {
+		if sess.isStale(resumeGracePeriod) {
+			delete(m.sessions, id)
+		}
+	}
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}